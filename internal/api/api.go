@@ -4,15 +4,27 @@ package api
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"path"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/manelmontilla/vulcan-runtime/runtime"
+	"github.com/manelmontilla/vulcan-runtime/runtime/observability"
 )
 
 // State defines the payload sent by the check when publishing their status.
@@ -25,34 +37,113 @@ type State struct {
 	Progress *float32       `json:"progress,omitempty"`
 }
 
+// checkEntry holds the state the Push API tracks for a registered check: the
+// channel its progress notifications are delivered to, the bearer token it
+// must present to authenticate its requests, and the [runtime.Tracker] used
+// to validate that any new status is a legal transition from the last one
+// reported.
+type checkEntry struct {
+	progress chan<- State
+	token    string
+	tracker  *runtime.Tracker
+}
+
 // Push implements the REST Push used called by the checks to communicate its
 // progress and results.
 type Push struct {
-	checks sync.Map
-	log    *slog.Logger
+	checks    sync.Map
+	log       *slog.Logger
+	tlsConfig *tls.Config
+	metrics   *observability.Metrics
+	tracer    trace.Tracer
+}
+
+// PushOption configures a [Push] created with [NewPush].
+type PushOption func(*Push)
+
+// WithTLSConfig serves the Push API over HTTPS using tlsConfig, which also
+// allows requiring mutual TLS between the checks and the runtime.
+func WithTLSConfig(tlsConfig *tls.Config) PushOption {
+	return func(p *Push) { p.tlsConfig = tlsConfig }
+}
+
+// WithMetrics records Prometheus metrics about the notifications handled by
+// the Push API using m.
+func WithMetrics(m *observability.Metrics) PushOption {
+	return func(p *Push) { p.metrics = m }
+}
+
+// WithTracer starts a span for every state update received by the Push API
+// using tracer, as a child of the span named by the request's W3C
+// traceparent header, if any.
+func WithTracer(tracer trace.Tracer) PushOption {
+	return func(p *Push) { p.tracer = tracer }
 }
 
 // NewPush creates a new HTTP server that listens for check progress
 // notifications.
-func NewPush(addr string) *Push {
-	log := slog.Default()
-	p := &Push{
-		log: log,
+func NewPush(opts ...PushOption) *Push {
+	p := &Push{log: slog.Default()}
+	for _, opt := range opts {
+		opt(p)
 	}
 	return p
 }
 
+// Register associates id with a new progress channel and bearer token, and
+// returns both. The token must be handed to the check, e.g. via the
+// RunParams.PushToken field, so it can authenticate its requests to the
+// Push API. The returned channel is closed when [Push.Unregister] is
+// called for id.
+func (p *Push) Register(id string) (<-chan State, string, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to generate push token for check %s: %w", id, err)
+	}
+	progress := make(chan State)
+	p.checks.Store(id, &checkEntry{progress: progress, token: token, tracker: runtime.NewTracker()})
+	return progress, token, nil
+}
+
+// Unregister removes id from the Push API and closes its progress channel.
+// It must be called once a check has reached a terminal state.
+func (p *Push) Unregister(id string) {
+	v, ok := p.checks.LoadAndDelete(id)
+	if !ok {
+		return
+	}
+	if entry, ok := v.(*checkEntry); ok {
+		close(entry.progress)
+	}
+}
+
+// newToken generates a random bearer token to authenticate a check's
+// requests to the Push API.
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
 // Start makes the Push API start listening for notifications. It will try to
 // gracefully stop listening when the passed context is cancelled. The returnned
 // channel will contain the result of the stop operation.
 func (p *Push) Start(ctx context.Context, addr string) <-chan error {
 	srv := &http.Server{
-		Addr:    addr,
-		Handler: http.HandlerFunc(p.handleHTTP),
+		Addr:      addr,
+		Handler:   http.HandlerFunc(p.handleHTTP),
+		TLSConfig: p.tlsConfig,
 	}
 	stopped := make(chan error, 1)
 	go func() {
-		err := srv.ListenAndServe()
+		var err error
+		if p.tlsConfig != nil {
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
 		stopped <- err
 	}()
 
@@ -89,6 +180,10 @@ func (p *Push) Start(ctx context.Context, addr string) <-chan error {
 func (p *Push) handleHTTP(w http.ResponseWriter, r *http.Request) {
 	// Checks pushes the information using requests of type: PATCH /check/$id.
 
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	w = rec
+	defer p.recordNotification(rec)
+
 	if r.Method != http.MethodPatch {
 		p.log.Error("unable to process check push notification, invalid method", "method", r.Method)
 		writeHTTPError(http.StatusBadRequest, "invalid method", w)
@@ -103,13 +198,26 @@ func (p *Push) handleHTTP(w http.ResponseWriter, r *http.Request) {
 		writeHTTPError(http.StatusBadRequest, "invalid path", w)
 		return
 	}
-	progress, ok := p.checks.Load(id)
+	v, ok := p.checks.Load(id)
 	if !ok {
 		p.log.Error("unable to process check push notification, check id not found", "id", id)
 		writeHTTPError(http.StatusBadRequest, "check id not found", w)
 		return
 	}
 
+	entry, ok := v.(*checkEntry)
+	if !ok {
+		p.log.Error("unable to process check push notification, unexpected entry type")
+		writeHTTPError(http.StatusInternalServerError, "", w)
+		return
+	}
+
+	if !hasValidToken(r, entry.token) {
+		p.log.Error("unable to process check push notification, invalid or missing auth token", "id", id)
+		writeHTTPError(http.StatusUnauthorized, "invalid auth token", w)
+		return
+	}
+
 	dec := json.NewDecoder(r.Body)
 	var s State
 	if err := dec.Decode(&s); err != nil {
@@ -118,20 +226,62 @@ func (p *Push) handleHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cprogress, ok := progress.(chan<- State)
-	if !ok {
-		p.log.Error("unable to process check push notification, unexpected channel type")
-		writeHTTPError(http.StatusInternalServerError, "", w)
-		return
+	if s.Status != nil {
+		if p.tracer != nil {
+			ctx := propagation.TraceContext{}.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			_, span := p.tracer.Start(ctx, "check.state", trace.WithAttributes(
+				attribute.String("check.id", id),
+				attribute.String("check.state", string(*s.Status)),
+			))
+			defer span.End()
+		}
+		if err := entry.tracker.Observe(*s.Status); err != nil {
+			p.log.Error("unable to process check push notification, invalid state transition", "id", id, "err", err)
+			writeHTTPError(http.StatusConflict, err.Error(), w)
+			return
+		}
 	}
 
-	cprogress <- s
+	entry.progress <- s
 	w.WriteHeader(http.StatusOK)
 }
 
+// hasValidToken reports whether r carries the Authorization bearer token
+// expected for a check.
+func hasValidToken(r *http.Request, want string) bool {
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return false
+	}
+	got := strings.TrimPrefix(auth, prefix)
+	return subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
+
 func writeHTTPError(status int, msg string, w http.ResponseWriter) {
 	w.WriteHeader(status)
 	if msg != "" {
 		w.Write([]byte(msg))
 	}
 }
+
+// statusRecorder wraps a [http.ResponseWriter], recording the HTTP status
+// code it is written with.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// recordNotification accounts for a handled Push API notification, labeled
+// with the HTTP status code the runtime responded with.
+func (p *Push) recordNotification(rec *statusRecorder) {
+	if p.metrics == nil {
+		return
+	}
+	p.metrics.PushNotifications.WithLabelValues(strconv.Itoa(rec.status)).Inc()
+}
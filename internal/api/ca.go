@@ -0,0 +1,149 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// CA is an in-memory certificate authority used to secure the Push API with
+// TLS, and optionally mutual TLS, between the checks and the runtime. It
+// exists for the lifetime of the process it was created in; it is not meant
+// to be persisted.
+type CA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// NewCA generates a new self-signed, in-memory certificate authority.
+func NewCA() (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate CA key: %w", err)
+	}
+	serial, err := randSerial()
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "vulcan-runtime"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create CA certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse CA certificate: %w", err)
+	}
+	return &CA{cert: cert, key: key}, nil
+}
+
+// ServerTLSConfig returns the [tls.Config] the Push API must use to serve
+// HTTPS. When mTLS is true, it also requires and verifies a client
+// certificate signed by ca.
+func (ca *CA) ServerTLSConfig(mTLS bool) (*tls.Config, error) {
+	serverCert, err := ca.issue(pkix.Name{CommonName: "vulcan-runtime"}, x509.ExtKeyUsageServerAuth, time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("unable to issue server certificate: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{*serverCert},
+	}
+	if mTLS {
+		pool := x509.NewCertPool()
+		pool.AddCert(ca.cert)
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// IssueClientCert issues a short-lived client certificate identifying the
+// check checkID, for it to use when calling the Push API over mutual TLS.
+func (ca *CA) IssueClientCert(checkID string) (tls.Certificate, error) {
+	cert, err := ca.issue(pkix.Name{CommonName: checkID}, x509.ExtKeyUsageClientAuth, time.Hour)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("unable to issue client certificate for check %s: %w", checkID, err)
+	}
+	return *cert, nil
+}
+
+// IssueClientCertPEM issues a short-lived client certificate identifying
+// the check checkID, PEM encoding the certificate and its private key so
+// they can be handed to a backend as [backend.RunParams.PushClientCert] and
+// [backend.RunParams.PushClientKey].
+func (ca *CA) IssueClientCertPEM(checkID string) (certPEM, keyPEM []byte, err error) {
+	cert, err := ca.IssueClientCert(checkID)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	key, ok := cert.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected client key type %T for check %s", cert.PrivateKey, checkID)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to marshal client key for check %s: %w", checkID, err)
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	return certPEM, keyPEM, nil
+}
+
+// Fingerprint returns the hex encoded SHA-256 fingerprint of the CA
+// certificate, so a check can pin it when dialing the Push API.
+func (ca *CA) Fingerprint() string {
+	sum := sha256.Sum256(ca.cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// issue generates a new key pair and certificate, signed by ca, for subject,
+// valid for ttl.
+func (ca *CA) issue(subject pkix.Name, usage x509.ExtKeyUsage, ttl time.Duration) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := randSerial()
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(ttl),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}, nil
+}
+
+// randSerial returns a random certificate serial number.
+func randSerial() (*big.Int, error) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	return rand.Int(rand.Reader, limit)
+}
@@ -1,10 +1,14 @@
 package checktype
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	checkcatalog "github.com/adevinta/vulcan-check-catalog/pkg/model"
+	"github.com/docker/docker/client"
 
 	"github.com/manelmontilla/vulcan-runtime/internal/dockerutil"
 )
@@ -53,16 +57,51 @@ type Image struct {
 	LastModified time.Time
 }
 
-// InspectImage returns the metadata about a checktype stored in an image.
+// InspectImage returns the metadata about a checktype stored in an image,
+// pulling it first if it is not already present in the local Docker daemon.
 func InspectImage(image string) (Image, error) {
 	cli, err := dockerutil.NewAPIClient()
 	if err != nil {
 		return Image{}, fmt.Errorf("unable to instantiate a docker client: %v", err)
 	}
+	ctx := context.Background()
+	if _, _, err := cli.ImageInspectWithRaw(ctx, image); err != nil {
+		if !client.IsErrNotFound(err) {
+			return Image{}, fmt.Errorf("unable to inspect image %s: %w", image, err)
+		}
+		if err := dockerutil.PullImage(ctx, cli, image, io.Discard); err != nil {
+			return Image{}, fmt.Errorf("unable to pull image %s: %w", image, err)
+		}
+	}
 	labels, err := dockerutil.ImageLabels(cli, image)
 	if err != nil {
 		return Image{}, fmt.Errorf("unable to read image labels: %w", err)
 	}
+	return imageFromLabels(image, labels)
+}
+
+// imageFromRef returns the metadata about a checktype stored in an image. It
+// tries to gather the metadata using a local Docker daemon and, when a
+// daemon is not reachable, falls back to inspecting the image directly
+// against its remote registry using [InspectImageRemote].
+func imageFromRef(ref string) (Image, error) {
+	image, err := InspectImage(ref)
+	if err == nil {
+		return image, nil
+	}
+	var errNoImage ErrNoChecktypeImage
+	if errors.As(err, &errNoImage) {
+		return Image{}, err
+	}
+	if !client.IsErrConnectionFailed(err) {
+		return Image{}, err
+	}
+	return InspectImageRemote(ref)
+}
+
+// imageFromLabels builds the metadata of a checktype image from the labels
+// read from it, either locally or from a remote registry.
+func imageFromLabels(image string, labels map[string]string) (Image, error) {
 	lastModified, ok := labels[lastModifiedTimeLabel]
 	if !ok {
 		err := ErrNoChecktypeImage{Image: image}
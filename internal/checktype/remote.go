@@ -0,0 +1,207 @@
+package checktype
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+
+	"github.com/distribution/distribution/v3"
+	"github.com/distribution/distribution/v3/manifest/manifestlist"
+	distclient "github.com/distribution/distribution/v3/registry/client"
+	"github.com/distribution/distribution/v3/registry/client/auth"
+	"github.com/distribution/distribution/v3/registry/client/auth/challenge"
+	"github.com/distribution/distribution/v3/registry/client/transport"
+	"github.com/distribution/reference"
+	"github.com/docker/cli/cli/config"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// InspectOption configures how [InspectImageRemote] resolves a checktype
+// image.
+type InspectOption func(*inspectOptions)
+
+// inspectOptions holds the options used by [InspectImageRemote].
+type inspectOptions struct {
+	os   string
+	arch string
+}
+
+// WithPlatform overrides the OS/architecture used to select a manifest when
+// the inspected reference points to a multi-arch manifest list. When not
+// set, InspectImageRemote defaults to the platform vulcan-runtime is running
+// on.
+func WithPlatform(os, arch string) InspectOption {
+	return func(o *inspectOptions) {
+		o.os = os
+		o.arch = arch
+	}
+}
+
+// InspectImageRemote returns the metadata about a checktype stored in an
+// image without requiring a local Docker daemon. It resolves ref against its
+// remote registry over the OCI Distribution v2 protocol, authenticating with
+// the credentials configured in the Docker CLI config file (including
+// registry-specific credential helpers such as ecr-login, gcr or acr), and
+// reads the checktype labels from the image config blob. When ref points to
+// a multi-arch manifest list, the manifest matching the current platform, or
+// the platform set with [WithPlatform], is used.
+func InspectImageRemote(ref string, opts ...InspectOption) (Image, error) {
+	options := inspectOptions{
+		os:   runtime.GOOS,
+		arch: runtime.GOARCH,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return Image{}, fmt.Errorf("unable to parse image ref %s: %w", ref, err)
+	}
+	named = reference.TagNameOnly(named)
+
+	repo, err := newRepository(named)
+	if err != nil {
+		return Image{}, fmt.Errorf("unable to create registry client for %s: %w", ref, err)
+	}
+
+	manifest, err := getManifest(repo, named, options)
+	if err != nil {
+		return Image{}, fmt.Errorf("unable to fetch manifest for %s: %w", ref, err)
+	}
+
+	labels, err := configLabels(repo, manifest)
+	if err != nil {
+		return Image{}, fmt.Errorf("unable to read image config for %s: %w", ref, err)
+	}
+
+	return imageFromLabels(ref, labels)
+}
+
+// newRepository builds a registry client for named, authenticating against
+// the registry with the credentials configured in the Docker CLI config
+// file.
+func newRepository(named reference.Named) (distclient.Repository, error) {
+	domain := reference.Domain(named)
+	baseURL := "https://" + domain
+
+	base := http.DefaultTransport
+	pingClient := &http.Client{Transport: base}
+	resp, err := pingClient.Get(baseURL + "/v2/")
+	if err != nil {
+		return nil, fmt.Errorf("unable to reach registry %s: %w", domain, err)
+	}
+	defer resp.Body.Close()
+
+	challengeManager := challenge.NewSimpleManager()
+	if err := challengeManager.AddResponse(resp); err != nil {
+		return nil, fmt.Errorf("unable to read registry challenge for %s: %w", domain, err)
+	}
+
+	user, pass, err := registryCredentials(domain)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credentials for %s: %w", domain, err)
+	}
+
+	creds := auth.NewSimpleCredentialStore(user, pass)
+	tokenHandler := auth.NewTokenHandlerWithOptions(auth.TokenHandlerOptions{
+		Transport:   base,
+		Credentials: creds,
+		Scopes: []auth.Scope{
+			auth.RepositoryScope{
+				Repository: reference.Path(named),
+				Actions:    []string{"pull"},
+			},
+		},
+	})
+	basicHandler := auth.NewBasicHandler(creds)
+	authTransport := transport.NewTransport(base, auth.NewAuthorizer(challengeManager, tokenHandler, basicHandler))
+
+	return distclient.NewRepository(named, baseURL, &http.Client{Transport: authTransport})
+}
+
+// registryCredentials resolves the username and password to use against a
+// registry using the credentials stored in the Docker CLI config file,
+// resolving registry-specific credential helpers (native keychain,
+// ecr-login, gcr, acr, ...) transparently.
+func registryCredentials(domain string) (user, pass string, err error) {
+	cfg := config.LoadDefaultConfigFile(io.Discard)
+	authConfig, err := cfg.GetAuthConfig(domain)
+	if err != nil {
+		return "", "", err
+	}
+	return authConfig.Username, authConfig.Password, nil
+}
+
+// getManifest fetches the manifest tagged in named, resolving a multi-arch
+// manifest list down to the entry matching options.os/options.arch.
+func getManifest(repo distclient.Repository, named reference.Named, options inspectOptions) (distribution.Manifest, error) {
+	ctx := context.Background()
+	ms, err := repo.Manifests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tagged, ok := named.(reference.Tagged)
+	if !ok {
+		return nil, fmt.Errorf("image ref %s has no tag", named.String())
+	}
+	manifest, err := ms.Get(ctx, "", distclient.WithTag(tagged.Tag()))
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := manifest.(*manifestlist.DeserializedManifestList)
+	if !ok {
+		return manifest, nil
+	}
+	desc, err := selectManifest(list.Manifests, options.os, options.arch)
+	if err != nil {
+		return nil, err
+	}
+	return ms.Get(ctx, desc.Digest)
+}
+
+// selectManifest returns the entry in manifests whose platform matches
+// os/arch, or an error if none does.
+func selectManifest(manifests []manifestlist.ManifestDescriptor, os, arch string) (manifestlist.ManifestDescriptor, error) {
+	for _, m := range manifests {
+		if m.Platform.OS == os && m.Platform.Architecture == arch {
+			return m, nil
+		}
+	}
+	return manifestlist.ManifestDescriptor{}, fmt.Errorf("no manifest found for platform %s/%s", os, arch)
+}
+
+// configLabels fetches the OCI image config blob referenced by manifest and
+// returns the labels defined in it.
+func configLabels(repo distclient.Repository, manifest distribution.Manifest) (map[string]string, error) {
+	_, payload, err := manifest.Payload()
+	if err != nil {
+		return nil, err
+	}
+	var m specs.Manifest
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return nil, fmt.Errorf("unable to parse manifest: %w", err)
+	}
+
+	ctx := context.Background()
+	rc, err := repo.Blobs(ctx).Open(ctx, m.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	configBytes, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	var image specs.Image
+	if err := json.Unmarshal(configBytes, &image); err != nil {
+		return nil, fmt.Errorf("unable to parse image config: %w", err)
+	}
+	return image.Config.Labels, nil
+}
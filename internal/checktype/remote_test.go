@@ -0,0 +1,33 @@
+package checktype
+
+import (
+	"testing"
+
+	"github.com/distribution/distribution/v3"
+	"github.com/distribution/distribution/v3/manifest/manifestlist"
+)
+
+func TestSelectManifest(t *testing.T) {
+	manifests := []manifestlist.ManifestDescriptor{
+		{
+			Descriptor: distribution.Descriptor{Digest: "sha256:linux-amd64"},
+			Platform:   manifestlist.PlatformSpec{OS: "linux", Architecture: "amd64"},
+		},
+		{
+			Descriptor: distribution.Descriptor{Digest: "sha256:linux-arm64"},
+			Platform:   manifestlist.PlatformSpec{OS: "linux", Architecture: "arm64"},
+		},
+	}
+
+	got, err := selectManifest(manifests, "linux", "arm64")
+	if err != nil {
+		t.Fatalf("selectManifest returned an error: %v", err)
+	}
+	if got.Digest != "sha256:linux-arm64" {
+		t.Errorf("selectManifest() digest = %s, want sha256:linux-arm64", got.Digest)
+	}
+
+	if _, err := selectManifest(manifests, "windows", "amd64"); err == nil {
+		t.Error("selectManifest() with no matching platform should return an error")
+	}
+}
@@ -5,6 +5,8 @@ package dockerutil
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
@@ -16,7 +18,9 @@ import (
 	"github.com/docker/cli/cli/flags"
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/go-connections/tlsconfig"
 )
 
@@ -74,6 +78,43 @@ func ImageLabels(cli client.APIClient, image string) (map[string]string, error)
 	return labels, nil
 }
 
+// PullImage pulls the image ref using cli, resolving the credentials for its
+// registry from the Docker CLI config file, including registry-specific
+// credential helpers (native keychain, ecr-login, gcr, acr, ...), and
+// streams the pull progress to progress.
+func PullImage(ctx context.Context, cli client.APIClient, ref string, progress io.Writer) error {
+	domain, _, _, err := ParseImageRef(ref)
+	if err != nil {
+		return err
+	}
+	authStr, err := registryAuth(domain)
+	if err != nil {
+		return err
+	}
+	rc, err := cli.ImagePull(ctx, ref, types.ImagePullOptions{RegistryAuth: authStr})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return jsonmessage.DisplayJSONMessagesStream(rc, progress, 0, false, nil)
+}
+
+// registryAuth returns the base64 encoded value of the X-Registry-Auth
+// header to use to authenticate against domain, resolved from the Docker CLI
+// config file.
+func registryAuth(domain string) (string, error) {
+	cfg := config.LoadDefaultConfigFile(io.Discard)
+	authConfig, err := cfg.GetAuthConfig(domain)
+	if err != nil {
+		return "", err
+	}
+	buf, err := json.Marshal(registry.AuthConfig(authConfig))
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(buf), nil
+}
+
 // ParseImageRef validates and enrich an image reference with domain (docker.io if
 // domain missing), tag (latest if missing).
 func ParseImageRef(ref string) (domain, path, tag string, err error) {
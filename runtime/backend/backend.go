@@ -21,6 +21,31 @@ type RunParams struct {
 	RequiredVars     []string
 	Metadata         map[string]string
 	RuntimeAddr      net.Addr
+
+	// PushToken is the bearer token the check must present, as an
+	// Authorization header, when calling back the Push API. It is
+	// injected as the VULCAN_CHECK_AUTH_TOKEN env var.
+	PushToken string
+
+	// PushCAFingerprint is the SHA-256 fingerprint of the CA the Push
+	// API's server certificate is signed with, so the check can pin it
+	// when dialing the Push API over TLS. It is injected as the
+	// VULCAN_PUSH_CA_FINGERPRINT env var.
+	PushCAFingerprint string
+
+	// PushClientCert and PushClientKey hold, PEM encoded, the short-lived
+	// client certificate issued to the check, used when the Push API
+	// requires mutual TLS. They are injected as the VULCAN_PUSH_CLIENT_CERT
+	// and VULCAN_PUSH_CLIENT_KEY env vars, which are empty when mTLS is not
+	// enabled.
+	PushClientCert []byte
+	PushClientKey  []byte
+
+	// Traceparent carries the W3C traceparent of the span the backend starts
+	// for this check in Run, injected as the TRACEPARENT env var so the
+	// check's own spans, if any, join the trace. The backends set it
+	// themselves, replacing any value passed in.
+	Traceparent string
 }
 
 // RunResult defines the info returned by the [Backend.Run] function.
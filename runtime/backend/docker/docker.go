@@ -0,0 +1,241 @@
+// Package docker implements a [backend.Backend] that runs each Vulcan check
+// as a local Docker container, using the same Docker CLI configuration and
+// credentials as [dockerutil.NewAPIClient].
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/manelmontilla/vulcan-runtime/internal/dockerutil"
+	"github.com/manelmontilla/vulcan-runtime/runtime"
+	"github.com/manelmontilla/vulcan-runtime/runtime/backend"
+	"github.com/manelmontilla/vulcan-runtime/runtime/observability"
+)
+
+// Config holds the configuration needed to run checks as local Docker
+// containers.
+type Config struct {
+	// Client is the Docker API client used to run checks. If nil,
+	// [dockerutil.NewAPIClient] is used instead.
+	Client client.APIClient
+
+	// RuntimeAddrOverride, when set, is used instead of
+	// [backend.RunParams.RuntimeAddr] as the value of the
+	// VULCAN_AGENT_ADDR environment variable injected into the check
+	// container, e.g. the address of the runtime as reachable from
+	// inside the Docker network the check runs in.
+	RuntimeAddrOverride string
+
+	// Metrics, when set, is used to record Prometheus metrics about the
+	// checks run by the backend.
+	Metrics *observability.Metrics
+
+	// TracerProvider, when set, is used to start a span for every check
+	// run by the backend. When nil, the globally registered
+	// [go.opentelemetry.io/otel.TracerProvider] is used.
+	TracerProvider trace.TracerProvider
+}
+
+// Backend runs Vulcan checks as local Docker containers. It implements
+// [backend.Backend].
+type Backend struct {
+	client client.APIClient
+	cfg    Config
+}
+
+var _ backend.Backend = (*Backend)(nil)
+
+// New creates a [Backend] using the given [Config]. If cfg.Client is nil, a
+// new Docker API client is created with [dockerutil.NewAPIClient].
+func New(cfg Config) (*Backend, error) {
+	cli := cfg.Client
+	if cli == nil {
+		var err error
+		cli, err = dockerutil.NewAPIClient()
+		if err != nil {
+			return nil, fmt.Errorf("unable to instantiate a docker client: %w", err)
+		}
+	}
+	return &Backend{client: cli, cfg: cfg}, nil
+}
+
+// Run pulls params.Image if it is not already present, runs it as a
+// container with the RunParams injected as env vars, streams its logs into
+// the returned [backend.RunResult] once the container exits, and stops and
+// removes the container, propagating the removal to ctx cancellation,
+// before that happens.
+func (b *Backend) Run(ctx context.Context, params backend.RunParams) (<-chan backend.RunResult, error) {
+	ctx, span := observability.Tracer(b.cfg.TracerProvider).Start(ctx, "docker.Backend.Run", trace.WithAttributes(
+		attribute.String("check.id", params.CheckID),
+		attribute.String("check.image", params.Image),
+	))
+
+	params.Traceparent = observability.Traceparent(ctx)
+
+	if err := b.ensureImage(ctx, params.Image); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, fmt.Errorf("unable to pull image %s: %w", params.Image, err)
+	}
+
+	name := "vulcan-check-" + params.CheckID
+	created, err := b.client.ContainerCreate(ctx, &container.Config{
+		Image: params.Image,
+		Env:   b.env(params),
+	}, nil, nil, nil, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, fmt.Errorf("unable to create container for check %s: %w", params.CheckID, err)
+	}
+
+	if err := b.client.ContainerStart(ctx, created.ID, types.ContainerStartOptions{}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, fmt.Errorf("unable to start container for check %s: %w", params.CheckID, err)
+	}
+
+	if b.cfg.Metrics != nil {
+		b.cfg.Metrics.ChecksStarted.Inc()
+		b.cfg.Metrics.ChecksRunning.Inc()
+	}
+
+	results := make(chan backend.RunResult, 1)
+	go b.watch(ctx, created.ID, params, time.Now(), span, results)
+	return results, nil
+}
+
+// ensureImage pulls image if it is not already present locally, recording
+// the pull's duration if b.cfg.Metrics is set.
+func (b *Backend) ensureImage(ctx context.Context, image string) error {
+	if _, _, err := b.client.ImageInspectWithRaw(ctx, image); err == nil {
+		return nil
+	} else if !client.IsErrNotFound(err) {
+		return err
+	}
+	started := time.Now()
+	err := dockerutil.PullImage(ctx, b.client, image, io.Discard)
+	if err == nil && b.cfg.Metrics != nil {
+		b.cfg.Metrics.ImagePullDuration.Observe(time.Since(started).Seconds())
+	}
+	return err
+}
+
+// env builds the env vars injected into the check container from params.
+func (b *Backend) env(params backend.RunParams) []string {
+	return []string{
+		"VULCAN_CHECK_ID=" + params.CheckID,
+		"VULCAN_CHECKTYPE_NAME=" + params.CheckTypeName,
+		"VULCAN_CHECKTYPE_VERSION=" + params.ChecktypeVersion,
+		"VULCAN_CHECK_TARGET=" + params.Target,
+		"VULCAN_CHECK_ASSET_TYPE=" + params.AssetType,
+		"VULCAN_CHECK_OPTIONS=" + params.Options,
+		"VULCAN_CHECK_REQUIRED_VARS=" + strings.Join(params.RequiredVars, ","),
+		"VULCAN_AGENT_ADDR=" + b.runtimeAddr(params),
+		"VULCAN_CHECK_AUTH_TOKEN=" + params.PushToken,
+		"VULCAN_PUSH_CA_FINGERPRINT=" + params.PushCAFingerprint,
+		"VULCAN_PUSH_CLIENT_CERT=" + string(params.PushClientCert),
+		"VULCAN_PUSH_CLIENT_KEY=" + string(params.PushClientKey),
+		"TRACEPARENT=" + params.Traceparent,
+	}
+}
+
+// runtimeAddr returns the address the check must use to reach the runtime
+// Push API, honoring [Config.RuntimeAddrOverride] when set.
+func (b *Backend) runtimeAddr(params backend.RunParams) string {
+	if b.cfg.RuntimeAddrOverride != "" {
+		return b.cfg.RuntimeAddrOverride
+	}
+	if params.RuntimeAddr != nil {
+		return params.RuntimeAddr.String()
+	}
+	return ""
+}
+
+// watch waits for the container identified by containerID to exit, or for
+// ctx to be cancelled, publishes the outcome to results, and records the
+// metrics and span started for the check by [Backend.Run].
+func (b *Backend) watch(ctx context.Context, containerID string, params backend.RunParams, started time.Time, span trace.Span, results chan<- backend.RunResult) {
+	defer close(results)
+	defer span.End()
+
+	statusCh, errCh := b.client.ContainerWait(ctx, containerID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		b.removeContainer(containerID)
+		b.finish(params, started, span, ctx.Err())
+		results <- backend.RunResult{Error: ctx.Err()}
+	case err := <-errCh:
+		err = fmt.Errorf("unable to wait for container %s: %w", containerID, err)
+		b.finish(params, started, span, err)
+		results <- backend.RunResult{Error: err}
+	case status := <-statusCh:
+		output, _ := b.containerLogs(ctx, containerID)
+		err := b.exitError(ctx, containerID, status.StatusCode)
+		b.removeContainer(containerID)
+		b.finish(params, started, span, err)
+		results <- backend.RunResult{Output: output, Error: err}
+	}
+}
+
+// exitError translates a container exit code into a terminal error, mapping
+// an out-of-memory kill to a check-specific error.
+func (b *Backend) exitError(ctx context.Context, containerID string, statusCode int64) error {
+	if statusCode == 0 {
+		return nil
+	}
+	if info, err := b.client.ContainerInspect(ctx, containerID); err == nil && info.State != nil && info.State.OOMKilled {
+		return fmt.Errorf("check failed: out of memory")
+	}
+	return fmt.Errorf("check failed: container exited with status %d", statusCode)
+}
+
+// finish records the metrics and span status for a check that has just
+// reached a terminal state. The backend only knows whether the container
+// itself exited cleanly, not the finer-grained terminal [runtime.State] the
+// check may have reported over the Push API, so ChecksFinished is labeled
+// with just runtime.StateFinished or runtime.StateFailed.
+func (b *Backend) finish(params backend.RunParams, started time.Time, span trace.Span, err error) {
+	state := runtime.StateFinished
+	if err != nil {
+		state = runtime.StateFailed
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	if b.cfg.Metrics == nil {
+		return
+	}
+	b.cfg.Metrics.ChecksRunning.Dec()
+	b.cfg.Metrics.ChecksFinished.WithLabelValues(state).Inc()
+	b.cfg.Metrics.CheckDuration.WithLabelValues(params.CheckTypeName).Observe(time.Since(started).Seconds())
+}
+
+// containerLogs returns the combined stdout/stderr logs of the container
+// identified by containerID.
+func (b *Backend) containerLogs(ctx context.Context, containerID string) ([]byte, error) {
+	rc, err := b.client.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true})
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// removeContainer force removes the container identified by containerID.
+func (b *Backend) removeContainer(containerID string) {
+	_ = b.client.ContainerRemove(context.Background(), containerID, types.ContainerRemoveOptions{Force: true})
+}
@@ -0,0 +1,359 @@
+// Package k8s implements a [backend.Backend] that runs each Vulcan check as
+// a Kubernetes batch/v1 Job instead of a plain Docker container.
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/manelmontilla/vulcan-runtime/runtime"
+	"github.com/manelmontilla/vulcan-runtime/runtime/backend"
+	"github.com/manelmontilla/vulcan-runtime/runtime/observability"
+)
+
+// jobNameLabel is the label used to relate a Job and its Pod back to the
+// check that originated it.
+const jobNameLabel = "vulcan.io/check-id"
+
+// Config holds the configuration needed to run checks as Kubernetes Jobs.
+type Config struct {
+	// Kubeconfig is the path to a kubeconfig file. When empty, the backend
+	// uses the in-cluster configuration.
+	Kubeconfig string
+
+	// Namespace is the namespace the Jobs are created into.
+	Namespace string
+
+	// RuntimeAddrOverride, when set, is used instead of
+	// [backend.RunParams.RuntimeAddr] as the value of the
+	// VULCAN_AGENT_ADDR environment variable injected into the check
+	// container, e.g. the name of a Service that fronts the runtime Push
+	// API, or a node IP reachable from inside the cluster.
+	RuntimeAddrOverride string
+
+	// JobTTLSeconds is the time a finished Job, and its Pod, are kept
+	// around before being garbage collected by Kubernetes. A zero value
+	// disables the TTL and leaves cleanup to the caller.
+	JobTTLSeconds int32
+
+	// Metrics, when set, is used to record Prometheus metrics about the
+	// checks run by the backend.
+	Metrics *observability.Metrics
+
+	// TracerProvider, when set, is used to start a span for every check
+	// run by the backend. When nil, the globally registered
+	// [go.opentelemetry.io/otel.TracerProvider] is used.
+	TracerProvider trace.TracerProvider
+}
+
+// Backend runs Vulcan checks as Kubernetes batch/v1 Jobs. It implements
+// [backend.Backend].
+type Backend struct {
+	clientset kubernetes.Interface
+	cfg       Config
+}
+
+var _ backend.Backend = (*Backend)(nil)
+
+// New creates a [Backend] using the given [Config]. If cfg.Kubeconfig is
+// empty, the backend uses the in-cluster configuration.
+func New(cfg Config) (*Backend, error) {
+	restCfg, err := restConfig(cfg.Kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build kubernetes client config: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create kubernetes client: %w", err)
+	}
+	return &Backend{clientset: clientset, cfg: cfg}, nil
+}
+
+func restConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig == "" {
+		return rest.InClusterConfig()
+	}
+	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+}
+
+// Run creates a Job that runs the check image described by params, streams
+// its logs into the returned [backend.RunResult] once the Job reaches a
+// terminal state, and deletes the Job, propagating the deletion to its Pod,
+// when ctx is cancelled before that happens.
+func (b *Backend) Run(ctx context.Context, params backend.RunParams) (<-chan backend.RunResult, error) {
+	ctx, span := observability.Tracer(b.cfg.TracerProvider).Start(ctx, "k8s.Backend.Run", trace.WithAttributes(
+		attribute.String("check.id", params.CheckID),
+		attribute.String("check.image", params.Image),
+	))
+
+	params.Traceparent = observability.Traceparent(ctx)
+
+	job := b.newJob(params)
+	created, err := b.clientset.BatchV1().Jobs(b.cfg.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, fmt.Errorf("unable to create job for check %s: %w", params.CheckID, err)
+	}
+
+	if b.cfg.Metrics != nil {
+		b.cfg.Metrics.ChecksStarted.Inc()
+		b.cfg.Metrics.ChecksRunning.Inc()
+	}
+
+	results := make(chan backend.RunResult, 1)
+	go b.watch(ctx, created.Name, params, time.Now(), span, results)
+	return results, nil
+}
+
+// newJob builds the Job spec used to run a check.
+func (b *Backend) newJob(params backend.RunParams) *batchv1.Job {
+	name := "vulcan-check-" + params.CheckID
+	labels := map[string]string{jobNameLabel: params.CheckID}
+	env := []corev1.EnvVar{
+		{Name: "VULCAN_CHECK_ID", Value: params.CheckID},
+		{Name: "VULCAN_CHECKTYPE_NAME", Value: params.CheckTypeName},
+		{Name: "VULCAN_CHECKTYPE_VERSION", Value: params.ChecktypeVersion},
+		{Name: "VULCAN_CHECK_TARGET", Value: params.Target},
+		{Name: "VULCAN_CHECK_ASSET_TYPE", Value: params.AssetType},
+		{Name: "VULCAN_CHECK_OPTIONS", Value: params.Options},
+		{Name: "VULCAN_CHECK_REQUIRED_VARS", Value: strings.Join(params.RequiredVars, ",")},
+		{Name: "VULCAN_AGENT_ADDR", Value: b.runtimeAddr(params)},
+		{Name: "VULCAN_CHECK_AUTH_TOKEN", Value: params.PushToken},
+		{Name: "VULCAN_PUSH_CA_FINGERPRINT", Value: params.PushCAFingerprint},
+		{Name: "VULCAN_PUSH_CLIENT_CERT", Value: string(params.PushClientCert)},
+		{Name: "VULCAN_PUSH_CLIENT_KEY", Value: string(params.PushClientKey)},
+		{Name: "TRACEPARENT", Value: params.Traceparent},
+	}
+
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: b.cfg.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "check",
+							Image: params.Image,
+							Env:   env,
+						},
+					},
+				},
+			},
+		},
+	}
+	if b.cfg.JobTTLSeconds > 0 {
+		job.Spec.TTLSecondsAfterFinished = &b.cfg.JobTTLSeconds
+	}
+	return job
+}
+
+// runtimeAddr returns the address the check must use to reach the runtime
+// Push API, honoring [Config.RuntimeAddrOverride] when set.
+func (b *Backend) runtimeAddr(params backend.RunParams) string {
+	if b.cfg.RuntimeAddrOverride != "" {
+		return b.cfg.RuntimeAddrOverride
+	}
+	if params.RuntimeAddr != nil {
+		return params.RuntimeAddr.String()
+	}
+	return ""
+}
+
+// watch follows the Job identified by jobName until it reaches a terminal
+// state, or ctx is cancelled, publishes the outcome to results, and records
+// the metrics and span started for the check by [Backend.Run].
+func (b *Backend) watch(ctx context.Context, jobName string, params backend.RunParams, started time.Time, span trace.Span, results chan<- backend.RunResult) {
+	defer close(results)
+	defer span.End()
+
+	for {
+		result, err := b.watchOnce(ctx, jobName)
+		if err != nil {
+			b.deleteJob(jobName)
+			b.finish(params, started, span, err)
+			results <- backend.RunResult{Error: err}
+			return
+		}
+		if result != nil {
+			b.finish(params, started, span, result.Error)
+			results <- *result
+			return
+		}
+		// The watch was closed by the apiserver, e.g. after its
+		// configured timeout, without the Job having reached a terminal
+		// state. This is routine watch-API behavior, not a failure of
+		// the Job; re-watch it.
+	}
+}
+
+// watchOnce checks the current state of the Job identified by jobName, in
+// case it already reached a terminal state while a previous watch was
+// being re-established, and otherwise watches it until it reaches a
+// terminal state or ctx is cancelled, returning the resulting
+// [backend.RunResult]. It returns a nil result and a nil error, without
+// having reached a terminal state, if the watch is closed by the apiserver,
+// so the caller can re-watch.
+func (b *Backend) watchOnce(ctx context.Context, jobName string) (*backend.RunResult, error) {
+	job, err := b.clientset.BatchV1().Jobs(b.cfg.Namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get job %s: %w", jobName, err)
+	}
+	if result, done := b.jobResult(ctx, job); done {
+		return &result, nil
+	}
+
+	watcher, err := b.clientset.BatchV1().Jobs(b.cfg.Namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector:   "metadata.name=" + jobName,
+		ResourceVersion: job.ResourceVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to watch job %s: %w", jobName, err)
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil, nil
+			}
+			job, ok := event.Object.(*batchv1.Job)
+			if !ok {
+				continue
+			}
+			if result, done := b.jobResult(ctx, job); done {
+				return &result, nil
+			}
+		}
+	}
+}
+
+// finish records the metrics and span status for a check that has just
+// reached a terminal state. The backend only knows whether the Job itself
+// succeeded or failed, not the finer-grained terminal [runtime.State] the
+// check may have reported over the Push API (e.g. StateTimeout or
+// StateInconclusive), so ChecksFinished is labeled with just
+// runtime.StateFinished or runtime.StateFailed.
+func (b *Backend) finish(params backend.RunParams, started time.Time, span trace.Span, err error) {
+	state := runtime.StateFinished
+	if err != nil {
+		state = runtime.StateFailed
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	if b.cfg.Metrics == nil {
+		return
+	}
+	b.cfg.Metrics.ChecksRunning.Dec()
+	b.cfg.Metrics.ChecksFinished.WithLabelValues(state).Inc()
+	b.cfg.Metrics.CheckDuration.WithLabelValues(params.CheckTypeName).Observe(time.Since(started).Seconds())
+}
+
+// jobResult inspects job's conditions and, once it has reached a terminal
+// state, fetches its Pod logs and translates the outcome into a
+// [backend.RunResult].
+func (b *Backend) jobResult(ctx context.Context, job *batchv1.Job) (backend.RunResult, bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			output, _ := b.podLogs(ctx, job.Name)
+			return backend.RunResult{Output: output}, true
+		case batchv1.JobFailed:
+			output, _ := b.podLogs(ctx, job.Name)
+			return backend.RunResult{Output: output, Error: b.failureError(ctx, job.Name, c)}, true
+		}
+	}
+	return backend.RunResult{}, false
+}
+
+// failureError derives a terminal error from a failed Job, mapping the
+// OOMKilled and DeadlineExceeded Pod termination reasons to check-specific
+// errors.
+func (b *Backend) failureError(ctx context.Context, jobName string, cond batchv1.JobCondition) error {
+	pod, err := b.jobPod(ctx, jobName)
+	if err != nil {
+		return fmt.Errorf("check failed: %s", cond.Reason)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Terminated == nil {
+			continue
+		}
+		switch cs.State.Terminated.Reason {
+		case "OOMKilled":
+			return fmt.Errorf("check failed: out of memory")
+		case "DeadlineExceeded":
+			return fmt.Errorf("check timed out")
+		}
+	}
+	if cond.Reason == "DeadlineExceeded" {
+		return fmt.Errorf("check timed out")
+	}
+	return fmt.Errorf("check failed: %s", cond.Reason)
+}
+
+// jobPod returns the single Pod created by the Job identified by jobName.
+func (b *Backend) jobPod(ctx context.Context, jobName string) (*corev1.Pod, error) {
+	pods, err := b.clientset.CoreV1().Pods(b.cfg.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "job-name=" + jobName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no pod found for job %s", jobName)
+	}
+	return &pods.Items[0], nil
+}
+
+// podLogs returns the logs of the Pod created by the Job identified by
+// jobName.
+func (b *Backend) podLogs(ctx context.Context, jobName string) ([]byte, error) {
+	pod, err := b.jobPod(ctx, jobName)
+	if err != nil {
+		return nil, err
+	}
+	req := b.clientset.CoreV1().Pods(b.cfg.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{})
+	rc, err := req.Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// deleteJob deletes the Job identified by jobName, propagating the deletion
+// to its Pod in the background.
+func (b *Backend) deleteJob(jobName string) {
+	propagation := metav1.DeletePropagationBackground
+	_ = b.clientset.BatchV1().Jobs(b.cfg.Namespace).Delete(context.Background(), jobName, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/manelmontilla/vulcan-sdk/check/report"
@@ -157,6 +158,93 @@ func (c States) IsTerminal(s State) bool {
 	return found
 }
 
+// level returns the index of the group s belongs to, and whether s was
+// found in the state machine at all.
+func (c States) level(s State) (int, bool) {
+	for i, group := range c {
+		if _, found := slices.BinarySearch(group, s); found {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// CanTransition reports whether a check is allowed to move from the state
+// from to the state to. A transition is legal when it moves to the
+// immediately following group in the state machine (so a terminal state can
+// only be entered from StateRunning), or when it is a RUNNING -> RUNNING
+// progress-only update.
+func (c States) CanTransition(from, to State) bool {
+	fromLevel, ok := c.level(from)
+	if !ok {
+		return false
+	}
+	toLevel, ok := c.level(to)
+	if !ok {
+		return false
+	}
+	if from == StateRunning && to == StateRunning {
+		return true
+	}
+	return toLevel == fromLevel+1
+}
+
+// Tracker atomically records the last state observed for a single
+// check, validating every new state against [CheckStates], and lets
+// multiple independent consumers (logger, metrics, report writer) observe
+// the stream of states without racing on the check itself.
+type Tracker struct {
+	mu    sync.Mutex
+	state State
+	subs  []chan State
+}
+
+// NewTracker creates a Tracker starting at StateCreated.
+func NewTracker() *Tracker {
+	return &Tracker{state: StateCreated}
+}
+
+// Observe records s as the new state if the transition from the last
+// observed state is legal, and broadcasts it to every subscriber. RUNNING ->
+// RUNNING progress-only updates are still broadcast, but otherwise coalesce
+// into the same state. It returns an error if the transition is not legal
+// according to [CheckStates].
+func (t *Tracker) Observe(s State) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !CheckStates.CanTransition(t.state, s) {
+		return fmt.Errorf("invalid state transition from %s to %s", t.state, s)
+	}
+	t.state = s
+	for _, sub := range t.subs {
+		select {
+		case sub <- s:
+		default:
+			// A slow subscriber doesn't block the others; it misses this
+			// update.
+		}
+	}
+	return nil
+}
+
+// Current returns the last state observed.
+func (t *Tracker) Current() State {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state
+}
+
+// Subscribe returns a channel that receives the states observed after the
+// call to Subscribe. The channel buffers one state; a state observed while
+// the buffer is full is not delivered to that subscriber.
+func (t *Tracker) Subscribe() <-chan State {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sub := make(chan State, 1)
+	t.subs = append(t.subs, sub)
+	return sub
+}
+
 // runningCheck contains the information about a check being run by a [Runtime].
 type runningCheck struct {
 	ID         string
@@ -165,10 +253,5 @@ type runningCheck struct {
 	Started    time.Time
 	FinalState *State
 	Report     *report.Report
-	progress   chan RunState
-}
-
-// running stores the information related to the checks run by a [Runtime].
-type running struct {
-	checks map[string]Check
+	progress   *Tracker
 }
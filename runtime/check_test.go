@@ -0,0 +1,31 @@
+package runtime
+
+import "testing"
+
+func TestStatesCanTransition(t *testing.T) {
+	tests := []struct {
+		name string
+		from State
+		to   State
+		want bool
+	}{
+		{"created to init", StateCreated, StateInit, true},
+		{"init to running", StateInit, StateRunning, true},
+		{"running to finished", StateRunning, StateFinished, true},
+		{"running progress update", StateRunning, StateRunning, true},
+		{"created straight to finished", StateCreated, StateFinished, false},
+		{"init straight to finished", StateInit, StateFinished, false},
+		{"created straight to running", StateCreated, StateRunning, false},
+		{"finished to finished", StateFinished, StateFinished, false},
+		{"finished to running", StateFinished, StateRunning, false},
+		{"unknown from state", State("BOGUS"), StateInit, false},
+		{"unknown to state", StateCreated, State("BOGUS"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckStates.CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
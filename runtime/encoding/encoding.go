@@ -0,0 +1,18 @@
+// Package encoding defines the interface used to translate a check
+// [report.Report] into a specific output format.
+package encoding
+
+import (
+	"io"
+
+	"github.com/manelmontilla/vulcan-sdk/check/report"
+)
+
+// ReportEncoder encodes the reports of a set of finished checks into a
+// specific output format. It receives the whole set, rather than being
+// called once per report, because some formats (e.g. SARIF) must emit a
+// single framed document covering every report rather than one per report.
+type ReportEncoder interface {
+	// Encode writes the encoding of reports to w.
+	Encode(w io.Writer, reports []report.Report) error
+}
@@ -0,0 +1,119 @@
+// Package ocsf implements a [encoding.ReportEncoder] that encodes a check
+// report as a stream of OCSF vulnerability_finding (class 2002) events.
+package ocsf
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/manelmontilla/vulcan-sdk/check/report"
+
+	"github.com/manelmontilla/vulcan-runtime/runtime/encoding"
+)
+
+const (
+	// classUIDVulnerabilityFinding is the OCSF class uid for the
+	// Vulnerability Finding event class.
+	classUIDVulnerabilityFinding = 2002
+
+	// categoryUIDFindings is the OCSF category uid for the Findings
+	// category.
+	categoryUIDFindings = 2
+
+	// activityIDCreate is the OCSF activity id used when a finding is
+	// reported for the first time.
+	activityIDCreate = 1
+)
+
+type event struct {
+	ClassUID    int         `json:"class_uid"`
+	CategoryUID int         `json:"category_uid"`
+	ActivityID  int         `json:"activity_id"`
+	SeverityID  int         `json:"severity_id"`
+	Time        int64       `json:"time"`
+	Message     string      `json:"message"`
+	FindingInfo findingInfo `json:"finding_info"`
+	Resources   []resource  `json:"resources,omitempty"`
+	Metadata    metadata    `json:"metadata"`
+}
+
+type findingInfo struct {
+	UID   string `json:"uid"`
+	Title string `json:"title"`
+	Desc  string `json:"desc"`
+}
+
+type resource struct {
+	UID  string `json:"uid"`
+	Type string `json:"type"`
+}
+
+type metadata struct {
+	Product product `json:"product"`
+}
+
+type product struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Encoder encodes a set of check [report.Report] as a stream of OCSF
+// vulnerability_finding events, one per vulnerability found by any of the
+// reports, each as a separate JSON object.
+type Encoder struct{}
+
+var _ encoding.ReportEncoder = Encoder{}
+
+// Encode writes the OCSF encoding of reports to w.
+func (Encoder) Encode(w io.Writer, reports []report.Report) error {
+	enc := json.NewEncoder(w)
+	now := time.Now().Unix()
+	for _, rep := range reports {
+		for _, v := range rep.Vulnerabilities {
+			ev := event{
+				ClassUID:    classUIDVulnerabilityFinding,
+				CategoryUID: categoryUIDFindings,
+				ActivityID:  activityIDCreate,
+				SeverityID:  severity(v.Score),
+				Time:        now,
+				Message:     v.Summary,
+				FindingInfo: findingInfo{
+					UID:   v.Fingerprint,
+					Title: v.Summary,
+					Desc:  v.Description,
+				},
+				Resources: []resource{{
+					UID:  rep.Target,
+					Type: rep.AssetType,
+				}},
+				Metadata: metadata{
+					Product: product{
+						Name:    rep.ChecktypeName,
+						Version: rep.ChecktypeVersion,
+					},
+				},
+			}
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// severity maps a CVSS score to an OCSF severity_id.
+func severity(score float32) int {
+	switch {
+	case score >= 9:
+		return 5 // Critical
+	case score >= 7:
+		return 4 // High
+	case score >= 4:
+		return 3 // Medium
+	case score > 0:
+		return 2 // Low
+	default:
+		return 1 // Informational
+	}
+}
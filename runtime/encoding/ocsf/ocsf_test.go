@@ -0,0 +1,24 @@
+package ocsf
+
+import "testing"
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		score float32
+		want  int
+	}{
+		{9.8, 5},
+		{9, 5},
+		{7, 4},
+		{6.9, 3},
+		{4, 3},
+		{3.9, 2},
+		{0.1, 2},
+		{0, 1},
+	}
+	for _, tt := range tests {
+		if got := severity(tt.score); got != tt.want {
+			t.Errorf("severity(%v) = %d, want %d", tt.score, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+// Package sarif implements a [encoding.ReportEncoder] that encodes a check
+// report as a SARIF 2.1.0 log, suitable for ingestion by GitHub code
+// scanning and other SARIF consumers.
+package sarif
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/manelmontilla/vulcan-sdk/check/report"
+
+	"github.com/manelmontilla/vulcan-runtime/runtime/encoding"
+)
+
+const schemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+// inconclusiveStates are the check states that Encode reports as a SARIF
+// toolExecutionNotification rather than as a result.
+var inconclusiveStates = map[string]bool{
+	"INCONCLUSIVE": true,
+	"FAILED":       true,
+	"MALFORMED":    true,
+	"KILLED":       true,
+	"TIMEOUT":      true,
+	"ABORTED":      true,
+}
+
+type log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []run  `json:"runs"`
+}
+
+type run struct {
+	Tool        tool         `json:"tool"`
+	Results     []result     `json:"results"`
+	Invocations []invocation `json:"invocations,omitempty"`
+}
+
+type tool struct {
+	Driver driver `json:"driver"`
+}
+
+type driver struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type result struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   message    `json:"message"`
+	Locations []location `json:"locations,omitempty"`
+}
+
+type message struct {
+	Text string `json:"text"`
+}
+
+type location struct {
+	PhysicalLocation physicalLocation `json:"physicalLocation"`
+}
+
+type physicalLocation struct {
+	ArtifactLocation artifactLocation `json:"artifactLocation"`
+}
+
+type artifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type invocation struct {
+	ExecutionSuccessful        bool           `json:"executionSuccessful"`
+	ToolExecutionNotifications []notification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type notification struct {
+	Message message `json:"message"`
+	Level   string  `json:"level"`
+}
+
+// Encoder encodes a check [report.Report] as a SARIF 2.1.0 log.
+type Encoder struct{}
+
+var _ encoding.ReportEncoder = Encoder{}
+
+// Encode writes a single SARIF 2.1.0 log to w, with one run per report in
+// reports.
+func (Encoder) Encode(w io.Writer, reports []report.Report) error {
+	doc := log{
+		Schema:  schemaURI,
+		Version: "2.1.0",
+		Runs:    make([]run, len(reports)),
+	}
+	for i, rep := range reports {
+		doc.Runs[i] = runFor(rep)
+	}
+	return json.NewEncoder(w).Encode(doc)
+}
+
+// runFor builds the SARIF run describing a single report.
+func runFor(rep report.Report) run {
+	r := run{
+		Tool: tool{
+			Driver: driver{
+				Name:    rep.ChecktypeName,
+				Version: rep.ChecktypeVersion,
+			},
+		},
+	}
+
+	for _, v := range rep.Vulnerabilities {
+		r.Results = append(r.Results, result{
+			RuleID:  v.Summary,
+			Level:   level(v.Score),
+			Message: message{Text: v.Description},
+			Locations: []location{{
+				PhysicalLocation: physicalLocation{
+					ArtifactLocation: artifactLocation{URI: rep.Target},
+				},
+			}},
+		})
+	}
+
+	if inconclusiveStates[rep.Status] {
+		r.Invocations = []invocation{{
+			ExecutionSuccessful: false,
+			ToolExecutionNotifications: []notification{{
+				Message: message{Text: rep.Error},
+				Level:   "error",
+			}},
+		}}
+	}
+
+	return r
+}
+
+// level maps a CVSS score to a SARIF result level.
+func level(score float32) string {
+	switch {
+	case score >= 9:
+		return "error"
+	case score >= 4:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,22 @@
+package sarif
+
+import "testing"
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		score float32
+		want  string
+	}{
+		{9.8, "error"},
+		{9, "error"},
+		{7.5, "warning"},
+		{4, "warning"},
+		{3.9, "note"},
+		{0, "note"},
+	}
+	for _, tt := range tests {
+		if got := level(tt.score); got != tt.want {
+			t.Errorf("level(%v) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Package observability provides the Prometheus metrics and OpenTelemetry
+// tracing instrumentation shared by the backend, api and runtime packages.
+package observability
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the Prometheus instruments exposed by vulcan-runtime.
+type Metrics struct {
+	// ChecksStarted counts every check that has started running.
+	ChecksStarted prometheus.Counter
+
+	// ChecksFinished counts every check that has reached a terminal
+	// state, labeled with that state.
+	ChecksFinished *prometheus.CounterVec
+
+	// PushNotifications counts every notification handled by the Push
+	// API, labeled with the HTTP status code the runtime responded with.
+	PushNotifications *prometheus.CounterVec
+
+	// ChecksRunning is the number of checks currently running.
+	ChecksRunning prometheus.Gauge
+
+	// CheckDuration observes the time a check takes to run, labeled with
+	// its checktype.
+	CheckDuration *prometheus.HistogramVec
+
+	// ImagePullDuration observes the time it takes to pull a checktype
+	// image.
+	ImagePullDuration prometheus.Histogram
+}
+
+// NewMetrics creates the vulcan_* Prometheus instruments and registers them
+// with reg. If reg is nil, [prometheus.DefaultRegisterer] is used.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	factory := promauto.With(reg)
+	return &Metrics{
+		ChecksStarted: factory.NewCounter(prometheus.CounterOpts{
+			Name: "vulcan_checks_started_total",
+			Help: "Total number of checks started.",
+		}),
+		ChecksFinished: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "vulcan_checks_finished_total",
+			Help: "Total number of checks finished, labeled with their final state.",
+		}, []string{"state"}),
+		PushNotifications: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "vulcan_push_notifications_total",
+			Help: "Total number of Push API notifications handled, labeled with the HTTP status code returned.",
+		}, []string{"code"}),
+		ChecksRunning: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "vulcan_checks_running",
+			Help: "Number of checks currently running.",
+		}),
+		CheckDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "vulcan_check_duration_seconds",
+			Help: "Duration of a check run, labeled with its checktype.",
+		}, []string{"checktype"}),
+		ImagePullDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Name: "vulcan_image_pull_duration_seconds",
+			Help: "Duration of pulling a checktype image.",
+		}),
+	}
+}
+
+// Handler returns the http.Handler that serves, in the Prometheus exposition
+// format, the metrics registered with [prometheus.DefaultRegisterer], which
+// include those created by [NewMetrics] when it is called with a nil reg.
+// Metrics registered with any other registerer are not served. It is meant
+// to be mounted at /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
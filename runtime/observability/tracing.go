@@ -0,0 +1,57 @@
+package observability
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceparentKey is the only key carried by the TRACEPARENT env var injected
+// into a check container.
+const traceparentKey = "traceparent"
+
+// traceparentCarrier adapts a single string value to
+// [propagation.TextMapCarrier], so a span context can be round-tripped
+// through the TRACEPARENT environment variable injected into a check
+// container.
+type traceparentCarrier struct {
+	value string
+}
+
+func (c *traceparentCarrier) Get(key string) string {
+	if key == traceparentKey {
+		return c.value
+	}
+	return ""
+}
+
+func (c *traceparentCarrier) Set(key, value string) {
+	if key == traceparentKey {
+		c.value = value
+	}
+}
+
+func (c *traceparentCarrier) Keys() []string {
+	return []string{traceparentKey}
+}
+
+// Traceparent returns the value of the TRACEPARENT env var that encodes the
+// span context carried by ctx, to be injected into a check container so its
+// own spans join the trace started for the check.
+func Traceparent(ctx context.Context) string {
+	carrier := &traceparentCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.value
+}
+
+// Tracer returns the tracer vulcan-runtime uses to create its spans, backed
+// by tp. If tp is nil, the globally registered [otel.TracerProvider] is used
+// instead.
+func Tracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer("github.com/manelmontilla/vulcan-runtime")
+}
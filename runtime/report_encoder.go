@@ -0,0 +1,33 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/manelmontilla/vulcan-sdk/check/report"
+
+	"github.com/manelmontilla/vulcan-runtime/runtime/encoding"
+	"github.com/manelmontilla/vulcan-runtime/runtime/encoding/ocsf"
+	"github.com/manelmontilla/vulcan-runtime/runtime/encoding/sarif"
+)
+
+// reportEncoders holds the [encoding.ReportEncoder] available to
+// [EncodeReports], indexed by format name.
+var reportEncoders = map[string]encoding.ReportEncoder{
+	"sarif": sarif.Encoder{},
+	"ocsf":  ocsf.Encoder{},
+}
+
+// EncodeReports writes, using the encoder registered for format, the
+// encoding of every report in reports to w. The supported formats are
+// "sarif" and "ocsf".
+func EncodeReports(w io.Writer, format string, reports []report.Report) error {
+	enc, ok := reportEncoders[format]
+	if !ok {
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+	if err := enc.Encode(w, reports); err != nil {
+		return fmt.Errorf("unable to encode reports: %w", err)
+	}
+	return nil
+}